@@ -0,0 +1,21 @@
+// Package core defines the small interfaces shared between the job and
+// worker packages, independent of any particular backing store.
+package core
+
+import "context"
+
+// QueuedMessage is the minimal contract a task payload must satisfy to be
+// handled by a Worker: the raw bytes that make up its wire-format payload.
+type QueuedMessage interface {
+	// Bytes returns the wire-format payload of the message.
+	Bytes() []byte
+}
+
+// Requeueable is an optional capability a QueuedMessage backed by
+// something other than an in-process channel (Redis, a database, ...) can
+// implement so it controls its own requeue semantics. A Worker should
+// type-assert for this before falling back to its own default requeue
+// path, analogous to asynq's RDB.Requeue.
+type Requeueable interface {
+	Requeue(ctx context.Context) error
+}
@@ -0,0 +1,161 @@
+package job
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// wireVersion is bumped whenever wireMessage's shape changes in a way that
+// isn't backward compatible.
+const wireVersion byte = 1
+
+// Codec encodes and decodes a Message to and from a wire format. The safe
+// codecs (JSONCodec, GobCodec) only touch fields that are actually
+// serializable, so messages can safely cross process, architecture, and
+// Go-version boundaries, e.g. a Redis- or NATS-backed queue. UnsafeCodec,
+// the legacy pointer-cast format, does not.
+type Codec interface {
+	Encode(m *Message) ([]byte, error)
+	Decode(data []byte) (*Message, error)
+}
+
+// wireMessage is the serializable shape of a Message. Task is a func and
+// can't travel on the wire, so its registered name does instead; Task is
+// resolved again on decode via RegisterTask.
+type wireMessage struct {
+	Version    byte          `json:"v"`
+	ID         string        `json:"id"`
+	TaskName   string        `json:"task,omitempty"`
+	Timeout    time.Duration `json:"timeout"`
+	Payload    []byte        `json:"body"`
+	RetryCount int64         `json:"retry_count"`
+	RetryDelay time.Duration `json:"retry_delay"`
+	Queue      string        `json:"queue,omitempty"`
+	Attempt    int64         `json:"attempt,omitempty"`
+}
+
+func toWire(m *Message) wireMessage {
+	return wireMessage{
+		Version:    wireVersion,
+		ID:         m.ID,
+		TaskName:   m.TaskName,
+		Timeout:    m.Timeout,
+		Payload:    m.Payload,
+		RetryCount: m.RetryCount,
+		RetryDelay: m.RetryDelay,
+		Queue:      m.Queue,
+		Attempt:    m.Attempt,
+	}
+}
+
+func fromWire(w wireMessage) (*Message, error) {
+	if w.Version != wireVersion {
+		return nil, fmt.Errorf("job: unsupported wire version %d", w.Version)
+	}
+
+	return &Message{
+		ID:         w.ID,
+		TaskName:   w.TaskName,
+		Task:       lookupTask(w.TaskName),
+		Timeout:    w.Timeout,
+		Payload:    w.Payload,
+		RetryCount: w.RetryCount,
+		RetryDelay: w.RetryDelay,
+		Queue:      w.Queue,
+		Attempt:    w.Attempt,
+	}, nil
+}
+
+// JSONCodec encodes messages as JSON. It is DefaultCodec.
+type JSONCodec struct{}
+
+// Encode implements Codec.
+func (JSONCodec) Encode(m *Message) ([]byte, error) {
+	return json.Marshal(toWire(m))
+}
+
+// Decode implements Codec.
+func (JSONCodec) Decode(data []byte) (*Message, error) {
+	var w wireMessage
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	return fromWire(w)
+}
+
+// GobCodec encodes messages using encoding/gob.
+type GobCodec struct{}
+
+// Encode implements Codec.
+func (GobCodec) Encode(m *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(toWire(m)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode implements Codec.
+func (GobCodec) Decode(data []byte) (*Message, error) {
+	var w wireMessage
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&w); err != nil {
+		return nil, err
+	}
+	return fromWire(w)
+}
+
+// DefaultCodec is the Codec used by Decode and by Message.Encode, unless a
+// Message opted into UnsafeCodec via WithUnsafeCodec.
+var DefaultCodec Codec = JSONCodec{}
+
+// movementSize is the size, in bytes, of a Message's in-memory layout.
+const movementSize = int(unsafe.Sizeof(Message{}))
+
+// UnsafeCodec is the legacy pointer-cast format, reinterpreting a
+// Message's raw memory as bytes. It is unsound across process,
+// architecture, or Go version boundaries because it serializes pointer
+// fields (Task, slice headers) verbatim. Kept only for single-process,
+// same-binary use via WithUnsafeCodec.
+type UnsafeCodec struct{}
+
+// Encode implements Codec.
+func (UnsafeCodec) Encode(m *Message) ([]byte, error) {
+	return (*[movementSize]byte)(unsafe.Pointer(m))[:], nil
+}
+
+// Decode implements Codec.
+func (UnsafeCodec) Decode(data []byte) (*Message, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("job: UnsafeCodec.Decode: empty data")
+	}
+	return (*Message)(unsafe.Pointer(&data[0])), nil
+}
+
+var (
+	handlerMu sync.RWMutex
+	handlers  = map[string]TaskFunc{}
+)
+
+// RegisterTask associates name with fn so a Message decoded from a safe
+// Codec (JSON/gob) can have Task resolved again: funcs aren't
+// serializable, so only the name travels on the wire. NewNamedTask calls
+// this for you.
+func RegisterTask(name string, fn TaskFunc) {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	handlers[name] = fn
+}
+
+func lookupTask(name string) TaskFunc {
+	if name == "" {
+		return nil
+	}
+	handlerMu.RLock()
+	defer handlerMu.RUnlock()
+	return handlers[name]
+}
@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	RegisterTask("codec-test-json", func(context.Context) error { return nil })
+
+	m := &Message{
+		ID:         "abc123",
+		TaskName:   "codec-test-json",
+		Timeout:    5 * time.Second,
+		Payload:    []byte("payload"),
+		RetryCount: 3,
+		RetryDelay: time.Second,
+		Queue:      "critical",
+		Attempt:    1,
+	}
+
+	data, err := JSONCodec{}.Encode(m)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := JSONCodec{}.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	assertMessageRoundTrip(t, m, got)
+	if got.Task == nil {
+		t.Fatal("Decode should have resolved Task via RegisterTask")
+	}
+}
+
+func TestGobCodecRoundTrip(t *testing.T) {
+	RegisterTask("codec-test-gob", func(context.Context) error { return nil })
+
+	m := &Message{
+		ID:         "def456",
+		TaskName:   "codec-test-gob",
+		Timeout:    time.Minute,
+		Payload:    []byte("other payload"),
+		RetryCount: 1,
+		RetryDelay: 200 * time.Millisecond,
+		Attempt:    0,
+	}
+
+	data, err := GobCodec{}.Encode(m)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := GobCodec{}.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	assertMessageRoundTrip(t, m, got)
+}
+
+func TestCodecDecodeRejectsUnknownWireVersion(t *testing.T) {
+	// a wire payload claiming a version other than the codec's wireVersion.
+	tampered := []byte(`{"v":99,"id":"xyz","timeout":0,"body":null,"retry_count":0,"retry_delay":0}`)
+
+	codec := JSONCodec{}
+	if _, err := codec.Decode(tampered); err == nil {
+		t.Fatal("Decode should reject an unsupported wire version")
+	}
+}
+
+func TestLookupTaskUnregisteredNameReturnsNil(t *testing.T) {
+	if fn := lookupTask("no-such-task-registered"); fn != nil {
+		t.Fatal("lookupTask should return nil for a name nothing registered")
+	}
+	if fn := lookupTask(""); fn != nil {
+		t.Fatal("lookupTask should return nil for an empty name")
+	}
+}
+
+func assertMessageRoundTrip(t *testing.T, want, got *Message) {
+	t.Helper()
+
+	if got.ID != want.ID {
+		t.Errorf("ID: want %q, got %q", want.ID, got.ID)
+	}
+	if got.TaskName != want.TaskName {
+		t.Errorf("TaskName: want %q, got %q", want.TaskName, got.TaskName)
+	}
+	if got.Timeout != want.Timeout {
+		t.Errorf("Timeout: want %v, got %v", want.Timeout, got.Timeout)
+	}
+	if string(got.Payload) != string(want.Payload) {
+		t.Errorf("Payload: want %q, got %q", want.Payload, got.Payload)
+	}
+	if got.RetryCount != want.RetryCount {
+		t.Errorf("RetryCount: want %d, got %d", want.RetryCount, got.RetryCount)
+	}
+	if got.RetryDelay != want.RetryDelay {
+		t.Errorf("RetryDelay: want %v, got %v", want.RetryDelay, got.RetryDelay)
+	}
+	if got.Queue != want.Queue {
+		t.Errorf("Queue: want %q, got %q", want.Queue, got.Queue)
+	}
+	if got.Attempt != want.Attempt {
+		t.Errorf("Attempt: want %d, got %d", want.Attempt, got.Attempt)
+	}
+}
@@ -3,8 +3,8 @@ package job
 import (
 	"context"
 	"time"
-	"unsafe"
 
+	"github.com/rs/xid"
 	"github.com/swordkee/queue/core"
 )
 
@@ -13,8 +13,19 @@ type TaskFunc func(context.Context) error
 
 // Message describes a task and its metadata.
 type Message struct {
+	// ID uniquely identifies this message, generated when it is built.
+	// Workers key their in-flight cancel registry off of it, so a
+	// specific running task can be looked up and cancelled by ID.
+	ID string `json:"id"`
+
 	Task TaskFunc `json:"-"`
 
+	// TaskName identifies the registered TaskFunc for this message when
+	// it's encoded with a safe Codec (JSON/gob): funcs can't travel on
+	// the wire, so the name does instead, and Task is resolved again via
+	// RegisterTask on Decode. Set by NewNamedTask; unused with UnsafeCodec.
+	TaskName string `json:"task,omitempty"`
+
 	// Timeout is the duration the task can be processed by Handler.
 	// zero if not specified
 	// default is 60 time.Minute
@@ -31,32 +42,55 @@ type Message struct {
 	// default is 100ms
 	RetryDelay time.Duration `json:"retry_delay"`
 
-	// Data to save Unsafe cast
-	Data []byte
-}
+	// Queue is the name of the named queue this message should be routed
+	// to by a worker that supports multiple queues (e.g. simple.Worker).
+	// empty means the worker's default queue.
+	Queue string `json:"queue,omitempty"`
 
-const (
-	movementSize = int(unsafe.Sizeof(Message{}))
-)
+	// Attempt is how many times this message has already been retried.
+	// It is incremented by the worker on each retry and compared against
+	// RetryCount to decide whether to give up.
+	Attempt int64 `json:"attempt,omitempty"`
+
+	// Data holds the bytes last produced by Encode.
+	Data []byte `json:"-"`
+
+	// useUnsafeCodec routes Encode through UnsafeCodec instead of
+	// DefaultCodec. Set via WithUnsafeCodec.
+	useUnsafeCodec bool
+}
 
 // Bytes get internal data
 func (m *Message) Bytes() []byte {
 	return m.Data
 }
 
-// Encode for encoding the structure
-func (m *Message) Encode() {
-	m.Data = Encode(m)
+// Encode serializes m into m.Data using DefaultCodec, or UnsafeCodec if m
+// was built with WithUnsafeCodec.
+func (m *Message) Encode() error {
+	codec := Codec(DefaultCodec)
+	if m.useUnsafeCodec {
+		codec = UnsafeCodec{}
+	}
+
+	data, err := codec.Encode(m)
+	if err != nil {
+		return err
+	}
+	m.Data = data
+	return nil
 }
 
 func NewMessage(m core.QueuedMessage, opts ...AllowOption) *Message {
 	o := NewOptions(opts...)
 
 	return &Message{
-		RetryCount: o.retryCount,
-		RetryDelay: o.retryDelay,
-		Timeout:    o.timeout,
-		Payload:    m.Bytes(),
+		ID:             xid.New().String(),
+		RetryCount:     o.retryCount,
+		RetryDelay:     o.retryDelay,
+		Timeout:        o.timeout,
+		Payload:        m.Bytes(),
+		useUnsafeCodec: o.unsafeCodec,
 	}
 }
 
@@ -64,17 +98,27 @@ func NewTask(task TaskFunc, opts ...AllowOption) *Message {
 	o := NewOptions(opts...)
 
 	return &Message{
-		Timeout:    o.timeout,
-		RetryCount: o.retryCount,
-		RetryDelay: o.retryDelay,
-		Task:       task,
+		ID:             xid.New().String(),
+		Timeout:        o.timeout,
+		RetryCount:     o.retryCount,
+		RetryDelay:     o.retryDelay,
+		Task:           task,
+		useUnsafeCodec: o.unsafeCodec,
 	}
 }
 
-func Encode(m *Message) []byte {
-	return (*[movementSize]byte)(unsafe.Pointer(m))[:]
+// NewNamedTask is like NewTask, but also registers task under name via
+// RegisterTask, so a Message encoded with a safe Codec (JSON/gob) can have
+// Task resolved again after a round trip through a backing store.
+func NewNamedTask(name string, task TaskFunc, opts ...AllowOption) *Message {
+	RegisterTask(name, task)
+	m := NewTask(task, opts...)
+	m.TaskName = name
+	return m
 }
 
-func Decode(m []byte) *Message {
-	return (*Message)(unsafe.Pointer(&m[0]))
+// Decode deserializes data using DefaultCodec. Data produced with
+// WithUnsafeCodec must instead be decoded with UnsafeCodec{}.Decode.
+func Decode(data []byte) (*Message, error) {
+	return DefaultCodec.Decode(data)
 }
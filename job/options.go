@@ -0,0 +1,67 @@
+package job
+
+import "time"
+
+const (
+	defaultTimeout    = 60 * time.Minute
+	defaultRetryDelay = 100 * time.Millisecond
+)
+
+// AllowOption configures a Message built via NewMessage, NewTask or
+// NewNamedTask.
+type AllowOption func(*options)
+
+type options struct {
+	timeout     time.Duration
+	retryCount  int64
+	retryDelay  time.Duration
+	unsafeCodec bool
+}
+
+// NewOptions applies opts over the package defaults.
+func NewOptions(opts ...AllowOption) *options {
+	o := &options{
+		timeout:    defaultTimeout,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithTimeout sets how long the task may run before it's considered timed
+// out. default is 60 minutes.
+func WithTimeout(d time.Duration) AllowOption {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithRetryCount sets how many times the task may be retried on failure.
+// default is 0, no retry.
+func WithRetryCount(n int64) AllowOption {
+	return func(o *options) {
+		o.retryCount = n
+	}
+}
+
+// WithRetryDelay sets the base delay between retries. default is 100ms.
+func WithRetryDelay(d time.Duration) AllowOption {
+	return func(o *options) {
+		o.retryDelay = d
+	}
+}
+
+// WithUnsafeCodec opts a Message into the legacy unsafe pointer-cast codec
+// (UnsafeCodec) instead of the default, portable DefaultCodec. Only use
+// this when the queue never leaves the current process and binary: the
+// unsafe codec serializes raw struct memory, including pointer fields, and
+// is not stable across architectures, Go versions, or process boundaries.
+func WithUnsafeCodec() AllowOption {
+	return func(o *options) {
+		o.unsafeCodec = true
+	}
+}
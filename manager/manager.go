@@ -0,0 +1,110 @@
+// Package manager exposes a running Worker's administrative operations
+// (flush, pause, resume, stats) over a Unix socket or HTTP endpoint, so an
+// operator can manage a live process the way they would with a mature
+// queue system, e.g. a small CLI such as:
+//
+//	queue-ctl flush --timeout=60s
+//	queue-ctl pause
+//	queue-ctl resume
+//	queue-ctl stats
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/swordkee/queue/simple"
+)
+
+// defaultFlushTimeout is used by handleFlush when the request doesn't
+// specify a timeout query parameter.
+const defaultFlushTimeout = 30 * time.Second
+
+// Manageable is the subset of simple.Worker's operational surface that
+// Manager exposes to operators.
+type Manageable interface {
+	Flush(ctx context.Context) error
+	Pause()
+	Resume()
+	Stats() simple.Stats
+}
+
+// Manager serves a Worker's administrative operations over HTTP, whether
+// that HTTP server listens on a Unix domain socket or a TCP address.
+type Manager struct {
+	worker Manageable
+	server *http.Server
+}
+
+// New wraps worker so its administrative operations can be served.
+func New(worker Manageable) *Manager {
+	m := &Manager{worker: worker}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/flush", m.handleFlush)
+	mux.HandleFunc("/pause", m.handlePause)
+	mux.HandleFunc("/resume", m.handleResume)
+	mux.HandleFunc("/stats", m.handleStats)
+	m.server = &http.Server{Handler: mux}
+
+	return m
+}
+
+// ListenUnix serves the administrative API on a Unix domain socket at
+// path, blocking until the listener is closed or Shutdown is called.
+func (m *Manager) ListenUnix(path string) error {
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("manager: listen on %s: %w", path, err)
+	}
+	return m.server.Serve(ln)
+}
+
+// ListenHTTP serves the administrative API on the given TCP address,
+// blocking until the listener is closed or Shutdown is called.
+func (m *Manager) ListenHTTP(addr string) error {
+	m.server.Addr = addr
+	return m.server.ListenAndServe()
+}
+
+// Shutdown stops serving the administrative API.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	return m.server.Shutdown(ctx)
+}
+
+func (m *Manager) handleFlush(w http.ResponseWriter, r *http.Request) {
+	timeout := defaultFlushTimeout
+	if v := r.URL.Query().Get("timeout"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			timeout = d
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	if err := m.worker.Flush(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Manager) handlePause(w http.ResponseWriter, _ *http.Request) {
+	m.worker.Pause()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Manager) handleResume(w http.ResponseWriter, _ *http.Request) {
+	m.worker.Resume()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Manager) handleStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(m.worker.Stats())
+}
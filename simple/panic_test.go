@@ -0,0 +1,101 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appleboy/queue"
+	"github.com/swordkee/queue/job"
+)
+
+// TestErrorHandlerReceivesPanicAsError verifies that a panicking task is
+// recovered and reported through ErrorHandler as an error carrying a stack
+// trace, rather than crashing the worker goroutine.
+func TestErrorHandlerReceivesPanicAsError(t *testing.T) {
+	handled := make(chan error, 1)
+
+	w := NewWorker(
+		WithErrorHandler(func(_ context.Context, _ *job.Message, err error) {
+			handled <- err
+		}),
+		WithRunFunc(func(context.Context, queue.QueuedMessage) error {
+			panic("boom")
+		}),
+	)
+
+	if err := w.Queue(job.NewTask(func(context.Context) error { return nil })); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+
+	go w.Run()
+	defer w.Shutdown()
+
+	select {
+	case err := <-handled:
+		if !strings.Contains(err.Error(), "boom") {
+			t.Fatalf("want error to mention the panic value, got %q", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ErrorHandler was never called")
+	}
+}
+
+// TestCancelStopsInFlightTask verifies that Cancel(id) cancels a specific
+// running task's context by ID, found via InFlight, and that Cancel reports
+// false for an ID no running task has.
+func TestCancelStopsInFlightTask(t *testing.T) {
+	idSeen := make(chan struct{})
+	cancelled := make(chan error, 1)
+
+	w := NewWorker(
+		WithRunFunc(func(ctx context.Context, _ queue.QueuedMessage) error {
+			close(idSeen)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return ctx.Err()
+		}),
+	)
+
+	msg := job.NewTask(func(context.Context) error { return nil })
+	if err := w.Queue(msg); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+
+	go w.Run()
+	defer w.Shutdown()
+
+	select {
+	case <-idSeen:
+	case <-time.After(time.Second):
+		t.Fatal("task never started")
+	}
+
+	ids := w.InFlight()
+	if len(ids) != 1 {
+		t.Fatalf("want 1 in-flight task, got %d", len(ids))
+	}
+	seenID := ids[0]
+	if seenID != msg.ID {
+		t.Fatalf("InFlight id %q doesn't match queued task id %q", seenID, msg.ID)
+	}
+
+	if !w.Cancel(seenID) {
+		t.Fatalf("Cancel(%q) reported no matching task", seenID)
+	}
+
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("task was never cancelled")
+	}
+
+	if w.Cancel("does-not-exist") {
+		t.Fatal("Cancel should report false for an unknown task ID")
+	}
+}
@@ -0,0 +1,71 @@
+package simple
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swordkee/queue/job"
+)
+
+// TestPriorityStrictDrainsHighestFirst verifies that under PriorityStrict,
+// queues are drained in the order they were registered (including the
+// default queue, which can be positioned via WithQueue), not in whatever
+// order dequeue happens to try them.
+func TestPriorityStrictDrainsHighestFirst(t *testing.T) {
+	w := NewWorker(
+		WithPriorityMode(PriorityStrict),
+		WithQueue("critical", 10, 1),
+		WithQueue(defaultQueueName, 10, 1),
+		WithQueue("low", 10, 1),
+	)
+
+	if err := w.QueueWithName("low", job.NewTask(func(context.Context) error { return nil })); err != nil {
+		t.Fatalf("Queue low: %v", err)
+	}
+	if err := w.Queue(job.NewTask(func(context.Context) error { return nil })); err != nil {
+		t.Fatalf("Queue default: %v", err)
+	}
+	if err := w.QueueWithName("critical", job.NewTask(func(context.Context) error { return nil })); err != nil {
+		t.Fatalf("Queue critical: %v", err)
+	}
+
+	name, _, ok := w.dequeue()
+	if !ok || name != "critical" {
+		t.Fatalf("want critical dequeued first, got %q (ok=%v)", name, ok)
+	}
+
+	name, _, ok = w.dequeue()
+	if !ok || name != defaultQueueName {
+		t.Fatalf("want %q dequeued second, got %q (ok=%v)", defaultQueueName, name, ok)
+	}
+
+	name, _, ok = w.dequeue()
+	if !ok || name != "low" {
+		t.Fatalf("want low dequeued third, got %q (ok=%v)", name, ok)
+	}
+}
+
+// TestPriorityWeightedFavorsHigherWeight verifies that under
+// PriorityWeighted, a queue registered with a higher weight is dequeued
+// from more often than one with a lower weight, over enough samples to
+// rule out the shuffle landing on the low-weight queue by chance.
+func TestPriorityWeightedFavorsHigherWeight(t *testing.T) {
+	w := NewWorker(
+		WithPriorityMode(PriorityWeighted),
+		WithQueue("critical", 10, 9),
+		WithQueue("low", 10, 1),
+	)
+
+	const rounds = 500
+	var criticalFirst int
+	for i := 0; i < rounds; i++ {
+		order := w.queueOrder()
+		if len(order) > 0 && order[0] == "critical" {
+			criticalFirst++
+		}
+	}
+
+	if criticalFirst < rounds/2 {
+		t.Fatalf("critical (weight 9) should be tried first more often than not, got %d/%d", criticalFirst, rounds)
+	}
+}
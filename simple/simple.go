@@ -3,27 +3,142 @@ package simple
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/appleboy/queue"
+	"github.com/swordkee/queue/core"
+	"github.com/swordkee/queue/job"
 )
 
 const defaultQueueSize = 4096
 
+// defaultQueueName is the queue used by Queue() and by callers that never
+// registered any named queues.
+const defaultQueueName = "default"
+
+// defaultShutdownTimeout is how long Shutdown waits for in-flight tasks to
+// finish on their own before forcibly cancelling them.
+const defaultShutdownTimeout = 8 * time.Second
+
+// defaultTaskTimeout is used when a task doesn't carry its own Timeout.
+const defaultTaskTimeout = 60 * time.Minute
+
+// defaultRetryBaseDelay and defaultRetryCapDelay parameterize
+// defaultRetryDelayFunc's exponential backoff.
+const (
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	defaultRetryCapDelay  = 30 * time.Second
+)
+
+// flushPollInterval is how often Flush rechecks whether the worker has
+// drained while waiting for it to do so.
+const flushPollInterval = 10 * time.Millisecond
+
 var _ queue.Worker = (*Worker)(nil)
 
 // Option for queue system
 type Option func(*Worker)
 
-var errMaxCapacity = errors.New("max capacity reached")
+var (
+	errMaxCapacity = errors.New("max capacity reached")
+	errNoSuchQueue = errors.New("queue is not registered")
+
+	// errShutdownRequeued is returned internally by handle's forced-quit
+	// branch once it has already given the task back via Requeue, so Run
+	// doesn't also run it through the ordinary failed/retry accounting path
+	// and requeue it a second time.
+	errShutdownRequeued = errors.New("simple: task requeued after forced shutdown")
+)
+
+// RetryDelayFunc computes how long to wait before re-enqueueing a task that
+// failed its nth attempt. n starts at 1.
+type RetryDelayFunc func(n int64, err error, m *job.Message) time.Duration
+
+// defaultRetryDelayFunc is exponential backoff with jitter:
+// min(cap, base*2^n) + rand(0, base). base is m.RetryDelay if the task set
+// one via job.WithRetryDelay, otherwise defaultRetryBaseDelay.
+func defaultRetryDelayFunc(n int64, _ error, m *job.Message) time.Duration {
+	base := defaultRetryBaseDelay
+	if m != nil && m.RetryDelay > 0 {
+		base = m.RetryDelay
+	}
+
+	backoff := base * time.Duration(int64(1)<<uint(n))
+	if backoff <= 0 || backoff > defaultRetryCapDelay {
+		backoff = defaultRetryCapDelay
+	}
+	return backoff + time.Duration(rand.Int63n(int64(base)))
+}
+
+// ErrorHandler is invoked when a task panics, with the recovered value
+// converted into an error carrying the captured stack trace. m is nil if
+// the task wasn't a *job.Message.
+type ErrorHandler func(ctx context.Context, m *job.Message, err error)
+
+// PriorityMode controls how Run selects among multiple named queues.
+type PriorityMode int
+
+const (
+	// PriorityWeighted distributes dequeues across queues according to
+	// their configured weights (weighted round-robin), reshuffled on every
+	// iteration, as in asynq's processor.
+	PriorityWeighted PriorityMode = iota
+	// PriorityStrict always drains higher priority queues to empty before
+	// a lower priority queue is looked at. Priority is the order the
+	// queues were registered in, highest first.
+	PriorityStrict
+)
 
-// Worker for simple queue using channel
+// Worker for simple queue using channel, with support for multiple named
+// queues that are multiplexed according to a configured PriorityMode.
 type Worker struct {
-	taskQueue chan queue.QueuedMessage
-	runFunc   func(context.Context, queue.QueuedMessage) error
-	stop      chan struct{}
-	logger    queue.Logger
-	stopOnce  sync.Once
+	queues       map[string]chan queue.QueuedMessage
+	queueNames   []string // registration order, highest priority first
+	queueWeights map[string]int
+	priorityMode PriorityMode
+	wake         chan struct{}
+	runFunc      func(context.Context, queue.QueuedMessage) error
+	stop         chan struct{} // closed to stop accepting new work
+	quit         chan struct{} // closed to force-cancel in-flight tasks
+	logger       queue.Logger
+	stopOnce     sync.Once
+
+	sema            chan struct{}
+	wg              sync.WaitGroup
+	shutdownTimeout time.Duration
+
+	retryDelayFunc RetryDelayFunc
+	errorHandler   ErrorHandler
+
+	cancelMu sync.Mutex
+	cancels  map[string]context.CancelFunc
+
+	pauseMu   sync.Mutex
+	paused    bool
+	pauseGate chan struct{} // nil while running; open while paused, closed by Resume
+
+	strandedMu sync.Mutex
+	stranded   []queue.QueuedMessage // tasks Requeue couldn't safely hand back to a queue
+
+	processed      int64 // atomic: tasks that finished without error
+	failed         int64 // atomic: tasks that finished with an error
+	retried        int64 // atomic: tasks scheduleRetry put back on a queue
+	pendingRetries int64 // atomic: tasks scheduleRetry is waiting on the backoff timer for
+}
+
+// Stats summarizes a Worker's activity since it was created.
+type Stats struct {
+	Pending      int
+	InFlight     int
+	Processed    int64
+	Failed       int64
+	Retried      int64
+	PendingRetry int64
 }
 
 // BeforeRun run script before start worker
@@ -36,14 +151,20 @@ func (s *Worker) AfterRun() error {
 	return nil
 }
 
-func (s *Worker) handle(m interface{}) error {
+func (s *Worker) handle(m queue.QueuedMessage) error {
 	// create channel with buffer size 1 to avoid goroutine leak
 	done := make(chan error, 1)
 	panicChan := make(chan interface{}, 1)
-	job, _ := m.(queue.Job)
-	ctx, cancel := context.WithTimeout(context.Background(), job.Timeout)
+	timeout := taskTimeout(m)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	msg, _ := m.(*job.Message)
+	if msg != nil && msg.ID != "" {
+		s.registerCancel(msg.ID, cancel)
+		defer s.deregisterCancel(msg.ID)
+	}
+
 	// run the job
 	go func() {
 		// handle panic issue
@@ -54,27 +175,95 @@ func (s *Worker) handle(m interface{}) error {
 		}()
 
 		// run custom process function
-		done <- s.runFunc(ctx, job)
+		done <- s.runFunc(ctx, m)
 	}()
 
 	select {
 	case p := <-panicChan:
-		panic(p)
+		err := fmt.Errorf("job: task panic: %v\n%s", p, debug.Stack())
+		if s.errorHandler != nil {
+			s.errorHandler(ctx, msg, err)
+		}
+		return err
 	case <-ctx.Done(): // timeout reached
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-			s.logger.Infof("job timeout: %s", job.Timeout.String())
+			s.logger.Infof("job timeout: %s", timeout.String())
 		}
 		// wait job
 		return <-done
-	case <-s.stop: // shutdown service
+	case <-s.quit: // forced cancellation after shutdown grace period
 		cancel()
-		// wait job
-		return <-done
+		<-done
+		// the task didn't get to finish on its own; give it back rather
+		// than letting its result be silently dropped. Report a sentinel
+		// instead of the task's own error so the caller doesn't also run
+		// it through scheduleRetry and requeue it a second time.
+		if rqErr := s.Requeue(m); rqErr != nil {
+			s.logger.Error(rqErr.Error())
+		}
+		return errShutdownRequeued
 	case err := <-done: // job finish and continue to worker
 		return err
 	}
 }
 
+// registerCancel records cancel under id so Cancel(id) and InFlight() can
+// find it while the task is running.
+func (s *Worker) registerCancel(id string, cancel context.CancelFunc) {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+	s.cancels[id] = cancel
+}
+
+// deregisterCancel removes id once its task has finished.
+func (s *Worker) deregisterCancel(id string) {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+	delete(s.cancels, id)
+}
+
+// Cancel cancels the in-flight task with the given ID, if one is running,
+// causing its context to be cancelled early. It reports whether a matching
+// task was found.
+func (s *Worker) Cancel(taskID string) bool {
+	s.cancelMu.Lock()
+	cancel, ok := s.cancels[taskID]
+	s.cancelMu.Unlock()
+	if !ok {
+		return false
+	}
+	cancel()
+	return true
+}
+
+// InFlight returns the IDs of tasks currently being processed.
+func (s *Worker) InFlight() []string {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+
+	ids := make([]string, 0, len(s.cancels))
+	for id := range s.cancels {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// taskTimeout reads the timeout to use for m, falling back to
+// defaultTaskTimeout if m doesn't carry one of its own.
+func taskTimeout(m queue.QueuedMessage) time.Duration {
+	switch t := m.(type) {
+	case *job.Message:
+		if t.Timeout > 0 {
+			return t.Timeout
+		}
+	case queue.Job:
+		if t.Timeout > 0 {
+			return t.Timeout
+		}
+	}
+	return defaultTaskTimeout
+}
+
 // Run start the worker
 func (s *Worker) Run() error {
 	// check queue status
@@ -84,53 +273,446 @@ func (s *Worker) Run() error {
 	default:
 	}
 
-	for task := range s.taskQueue {
-		if err := s.handle(task); err != nil {
+	for {
+		s.waitIfPaused()
+
+		name, task, ok := s.dequeue()
+		if !ok {
+			select {
+			case <-s.stop:
+				return nil
+			case <-s.wake:
+			}
+			continue
+		}
+
+		select {
+		case s.sema <- struct{}{}:
+		case <-s.quit:
+			// shutdown grace period elapsed while waiting for a free slot.
+			// task is already out of its queue at this point, so give it
+			// back rather than silently dropping it.
+			if err := s.Requeue(task); err != nil {
+				s.logger.Error(err.Error())
+			}
+			return nil
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-s.sema }()
+
+			if err := s.handle(task); err != nil {
+				if errors.Is(err, errShutdownRequeued) {
+					// already requeued by handle; don't double-count or
+					// double-requeue via scheduleRetry.
+					return
+				}
+				atomic.AddInt64(&s.failed, 1)
+				s.logger.Error(err.Error())
+				s.scheduleRetry(name, task, err)
+				return
+			}
+			atomic.AddInt64(&s.processed, 1)
+		}()
+	}
+}
+
+// waitIfPaused blocks while the worker is paused, returning as soon as
+// Resume is called (or the worker is told to stop or force-quit).
+func (s *Worker) waitIfPaused() {
+	s.pauseMu.Lock()
+	gate := s.pauseGate
+	s.pauseMu.Unlock()
+
+	if gate == nil {
+		return
+	}
+
+	select {
+	case <-gate:
+	case <-s.stop:
+	case <-s.quit:
+	}
+}
+
+// dequeue picks the next task to run according to the configured
+// PriorityMode, trying each queue in priority order and returning the first
+// task found, along with the name of the queue it came from. ok is false
+// if every queue is currently empty.
+func (s *Worker) dequeue() (string, queue.QueuedMessage, bool) {
+	for _, name := range s.queueOrder() {
+		select {
+		case task := <-s.queues[name]:
+			return name, task, true
+		default:
+		}
+	}
+	return "", nil, false
+}
+
+// queueOrder returns the queue names to poll this iteration, highest
+// priority first. Under PriorityStrict this is simply the registration
+// order. Under PriorityWeighted it is a fresh weighted shuffle, matching the
+// approach asynq's processor uses to multiplex queues.
+func (s *Worker) queueOrder() []string {
+	if s.priorityMode == PriorityStrict {
+		return s.queueNames
+	}
+
+	pool := make([]string, 0, len(s.queueNames))
+	for _, name := range s.queueNames {
+		weight := s.queueWeights[name]
+		if weight < 1 {
+			weight = 1
+		}
+		for i := 0; i < weight; i++ {
+			pool = append(pool, name)
+		}
+	}
+
+	rand.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+
+	seen := make(map[string]bool, len(s.queueNames))
+	order := make([]string, 0, len(s.queueNames))
+	for _, name := range pool {
+		if !seen[name] {
+			seen[name] = true
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// scheduleRetry re-enqueues a task that failed, after a backoff delay,
+// provided it has retries left. Message.Attempt is compared against
+// Message.RetryCount to decide whether to give up; only *job.Message tasks
+// can be retried this way, since that's where both counters live.
+func (s *Worker) scheduleRetry(name string, task queue.QueuedMessage, taskErr error) {
+	msg, ok := task.(*job.Message)
+	if !ok || msg.RetryCount <= 0 || msg.Attempt >= msg.RetryCount {
+		return
+	}
+
+	next := *msg
+	next.Attempt++
+
+	delayFunc := s.retryDelayFunc
+	if delayFunc == nil {
+		delayFunc = defaultRetryDelayFunc
+	}
+	delay := delayFunc(next.Attempt, taskErr, &next)
+
+	atomic.AddInt64(&s.retried, 1)
+	atomic.AddInt64(&s.pendingRetries, 1)
+	time.AfterFunc(delay, func() {
+		defer atomic.AddInt64(&s.pendingRetries, -1)
+
+		if err := s.QueueWithName(name, &next); err != nil {
+			if errors.Is(err, queue.ErrQueueShutdown) {
+				// the worker stopped accepting new work while this retry
+				// was waiting on its backoff timer; give it back the same
+				// way a forcibly-cancelled in-flight task would be.
+				if rqErr := s.Requeue(&next); rqErr != nil {
+					s.logger.Error(rqErr.Error())
+				}
+				return
+			}
 			s.logger.Error(err.Error())
 		}
+	})
+}
+
+// Requeue pushes a task back onto the queue it came from (or the default
+// queue, for tasks that don't say), without otherwise altering it. Workers
+// use this to give back tasks that were still in flight when the shutdown
+// grace period elapsed, rather than dropping them. Tasks backed by a store
+// that implements core.Requeueable control their own requeue behavior
+// instead of going through the Worker's in-memory queue.
+//
+// Once the worker has stopped accepting new work (Shutdown has been
+// called), pushing back onto the in-memory queue is unsound: Run may
+// already have returned, leaving nothing to ever drain the channel again.
+// In that case the task is stranded instead, recoverable via Stranded.
+func (s *Worker) Requeue(task queue.QueuedMessage) error {
+	if rq, ok := task.(core.Requeueable); ok {
+		return rq.Requeue(context.Background())
 	}
-	return nil
+
+	select {
+	case <-s.stop:
+		s.strand(task)
+		return nil
+	default:
+	}
+
+	name := defaultQueueName
+	if msg, ok := task.(*job.Message); ok && msg.Queue != "" {
+		name = msg.Queue
+	}
+
+	ch, ok := s.queues[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errNoSuchQueue, name)
+	}
+
+	select {
+	case ch <- task:
+		s.signal()
+		return nil
+	default:
+		return errMaxCapacity
+	}
+}
+
+// strand records task as unable to be safely requeued, for later recovery
+// via Stranded.
+func (s *Worker) strand(task queue.QueuedMessage) {
+	s.strandedMu.Lock()
+	defer s.strandedMu.Unlock()
+	s.stranded = append(s.stranded, task)
+}
+
+// Stranded returns the tasks Requeue couldn't safely hand back to a queue
+// because the worker had already stopped accepting new work, clearing the
+// worker's record of them. Callers should persist or re-submit these to a
+// fresh worker; otherwise they are lost.
+func (s *Worker) Stranded() []queue.QueuedMessage {
+	s.strandedMu.Lock()
+	defer s.strandedMu.Unlock()
+
+	out := s.stranded
+	s.stranded = nil
+	return out
 }
 
-// Shutdown worker
+// signal wakes Run up when it is blocked waiting for work.
+func (s *Worker) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Shutdown stops the worker accepting new work and waits for in-flight
+// tasks to finish. If tasks are still running after the shutdown timeout
+// (WithShutdownTimeout, default defaultShutdownTimeout), their
+// contexts are cancelled so they can return early rather than blocking
+// Shutdown indefinitely.
 func (s *Worker) Shutdown() error {
 	s.stopOnce.Do(func() {
 		close(s.stop)
-		close(s.taskQueue)
+
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+
+		timeout := s.shutdownTimeout
+		if timeout <= 0 {
+			timeout = defaultShutdownTimeout
+		}
+
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			close(s.quit)
+			<-done
+		}
 	})
 	return nil
 }
 
 // Capacity for channel
 func (s *Worker) Capacity() int {
-	return cap(s.taskQueue)
+	total := 0
+	for _, ch := range s.queues {
+		total += cap(ch)
+	}
+	return total
 }
 
 // Usage for count of channel usage
 func (s *Worker) Usage() int {
-	return len(s.taskQueue)
+	total := 0
+	for _, ch := range s.queues {
+		total += len(ch)
+	}
+	return total
+}
+
+// Flush blocks until the worker has no pending, in-flight, or
+// awaiting-retry tasks left, or ctx is done, whichever comes first.
+func (s *Worker) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(flushPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.Usage() == 0 && len(s.InFlight()) == 0 && atomic.LoadInt64(&s.pendingRetries) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
 
-// Queue send notification to queue
+// Pause stops Run from dequeueing new work without shutting the worker
+// down: tasks already in flight keep running, and Queue/QueueWithName
+// continue to accept new work, it just won't be picked up until Resume.
+func (s *Worker) Pause() {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	if s.paused {
+		return
+	}
+	s.paused = true
+	s.pauseGate = make(chan struct{})
+}
+
+// Resume undoes a prior Pause, letting Run dequeue work again.
+func (s *Worker) Resume() {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	if !s.paused {
+		return
+	}
+	s.paused = false
+	close(s.pauseGate)
+	s.signal()
+}
+
+// Stats reports counts of pending, in-flight, processed, failed, and
+// retried tasks since the worker was created, plus retries currently
+// waiting on their backoff timer (PendingRetry).
+func (s *Worker) Stats() Stats {
+	return Stats{
+		Pending:      s.Usage(),
+		InFlight:     len(s.InFlight()),
+		Processed:    atomic.LoadInt64(&s.processed),
+		Failed:       atomic.LoadInt64(&s.failed),
+		Retried:      atomic.LoadInt64(&s.retried),
+		PendingRetry: atomic.LoadInt64(&s.pendingRetries),
+	}
+}
+
+// Queue send notification to the default queue
 func (s *Worker) Queue(job queue.QueuedMessage) error {
+	return s.QueueWithName(defaultQueueName, job)
+}
+
+// QueueWithName send notification to the named queue. The queue must have
+// been registered with WithQueue (the default queue is always registered).
+func (s *Worker) QueueWithName(name string, task queue.QueuedMessage) error {
 	select {
 	case <-s.stop:
 		return queue.ErrQueueShutdown
 	default:
 	}
 
+	ch, ok := s.queues[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errNoSuchQueue, name)
+	}
+
+	// stamp the originating queue name so Requeue later knows where to
+	// put this task back, rather than assuming the default queue.
+	if msg, ok := task.(*job.Message); ok {
+		msg.Queue = name
+	}
+
 	select {
-	case s.taskQueue <- job:
+	case ch <- task:
+		s.signal()
 		return nil
 	default:
 		return errMaxCapacity
 	}
 }
 
-// WithQueueNum setup the capcity of queue
+// WithQueueNum setup the capcity of the default queue
 func WithQueueNum(num int) Option {
 	return func(w *Worker) {
-		w.taskQueue = make(chan queue.QueuedMessage, num)
+		w.queues[defaultQueueName] = make(chan queue.QueuedMessage, num)
+	}
+}
+
+// WithQueue registers a named queue with the given buffer size and weight,
+// including the default queue. weight is only consulted under
+// PriorityWeighted; under PriorityStrict queues are drained in the order
+// they were registered instead, highest priority first. Calling WithQueue
+// again for a name already registered (e.g. defaultQueueName, which is
+// always implicitly registered) repositions it to this point in that
+// order, so a standard critical > default > low layout can be built with
+// WithQueue("critical", ...), WithQueue(defaultQueueName, ...),
+// WithQueue("low", ...).
+func WithQueue(name string, size, weight int) Option {
+	return func(w *Worker) {
+		w.removeQueueName(name)
+		w.queueNames = append(w.queueNames, name)
+		w.queues[name] = make(chan queue.QueuedMessage, size)
+		w.queueWeights[name] = weight
+	}
+}
+
+// removeQueueName removes name from queueNames if present, so WithQueue can
+// reposition an already-registered queue instead of silently no-oping.
+func (w *Worker) removeQueueName(name string) {
+	for i, n := range w.queueNames {
+		if n == name {
+			w.queueNames = append(w.queueNames[:i], w.queueNames[i+1:]...)
+			return
+		}
+	}
+}
+
+// WithPriorityMode sets how Run selects among multiple named queues.
+func WithPriorityMode(mode PriorityMode) Option {
+	return func(w *Worker) {
+		w.priorityMode = mode
+	}
+}
+
+// WithConcurrency sets how many tasks Run will process at once. Each task
+// runs in its own goroutine, gated by a counting semaphore of size n.
+// The default concurrency is 1, i.e. tasks run one at a time as before.
+func WithConcurrency(n int) Option {
+	return func(w *Worker) {
+		w.sema = make(chan struct{}, n)
+	}
+}
+
+// WithShutdownTimeout sets how long Shutdown waits for in-flight tasks to
+// finish on their own before force-cancelling them. The default is
+// defaultShutdownTimeout.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(w *Worker) {
+		w.shutdownTimeout = d
+	}
+}
+
+// WithRetryDelayFunc overrides the backoff used between retries. The
+// default is exponential backoff with jitter (defaultRetryDelayFunc).
+func WithRetryDelayFunc(fn RetryDelayFunc) Option {
+	return func(w *Worker) {
+		w.retryDelayFunc = fn
+	}
+}
+
+// WithErrorHandler sets the handler invoked when a task panics. The error
+// passed to it wraps the recovered value with a captured stack trace.
+func WithErrorHandler(fn ErrorHandler) Option {
+	return func(w *Worker) {
+		w.errorHandler = fn
 	}
 }
 
@@ -151,9 +733,16 @@ func WithLogger(l queue.Logger) Option {
 // NewWorker for struc
 func NewWorker(opts ...Option) *Worker {
 	w := &Worker{
-		taskQueue: make(chan queue.QueuedMessage, defaultQueueSize),
-		stop:      make(chan struct{}),
-		logger:    queue.NewLogger(),
+		queues: map[string]chan queue.QueuedMessage{
+			defaultQueueName: make(chan queue.QueuedMessage, defaultQueueSize),
+		},
+		queueWeights: map[string]int{defaultQueueName: 1},
+		wake:         make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+		quit:         make(chan struct{}),
+		sema:         make(chan struct{}, 1),
+		cancels:      make(map[string]context.CancelFunc),
+		logger:       queue.NewLogger(),
 		runFunc: func(context.Context, queue.QueuedMessage) error {
 			return nil
 		},
@@ -165,5 +754,21 @@ func NewWorker(opts ...Option) *Worker {
 		opt(w)
 	}
 
+	// The default queue is always registered, but unless an option
+	// positioned it explicitly (WithQueue(defaultQueueName, ...)), it
+	// still needs a place in priority order: append it last rather than
+	// hardcoding it first, so PriorityStrict isn't forced to always drain
+	// it ahead of every queue an option registered.
+	hasDefault := false
+	for _, name := range w.queueNames {
+		if name == defaultQueueName {
+			hasDefault = true
+			break
+		}
+	}
+	if !hasDefault {
+		w.queueNames = append(w.queueNames, defaultQueueName)
+	}
+
 	return w
 }
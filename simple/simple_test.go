@@ -0,0 +1,114 @@
+package simple
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appleboy/queue"
+	"github.com/swordkee/queue/job"
+)
+
+// TestShutdownRequeuesInFlightAndWaitingTasks reproduces the scenario from
+// the chunk0-2/chunk0-3 review: with concurrency 1, one task ignores its
+// context and blocks past ShutdownTimeout while a second task is still
+// waiting for a free slot. Neither task should vanish: the first is
+// requeued by handle's forced-quit branch, the second by Run's own
+// semaphore-wait branch, and both end up in Stranded rather than being
+// silently dropped or double-counted.
+func TestShutdownRequeuesInFlightAndWaitingTasks(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	w := NewWorker(
+		WithConcurrency(1),
+		WithShutdownTimeout(20*time.Millisecond),
+		WithRunFunc(func(ctx context.Context, _ queue.QueuedMessage) error {
+			close(started)
+			select {
+			case <-release:
+			case <-time.After(time.Second):
+			}
+			return nil
+		}),
+	)
+	defer close(release)
+
+	if err := w.Queue(job.NewTask(func(context.Context) error { return nil })); err != nil {
+		t.Fatalf("Queue task 1: %v", err)
+	}
+	if err := w.Queue(job.NewTask(func(context.Context) error { return nil })); err != nil {
+		t.Fatalf("Queue task 2: %v", err)
+	}
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- w.Run() }()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("task 1 never started")
+	}
+
+	if err := w.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	<-runErr
+
+	stranded := w.Stranded()
+	if len(stranded) != 2 {
+		t.Fatalf("want 2 stranded tasks after forced shutdown, got %d", len(stranded))
+	}
+
+	stats := w.Stats()
+	if stats.Failed != 0 || stats.Retried != 0 {
+		t.Fatalf("forced-shutdown requeue should not be counted as failed/retried, got %+v", stats)
+	}
+}
+
+// TestFlushWaitsForPendingRetry reproduces the chunk0-6 review comment:
+// Flush must not report done while a failed task is still waiting on its
+// retry backoff timer.
+func TestFlushWaitsForPendingRetry(t *testing.T) {
+	const backoff = 100 * time.Millisecond
+
+	var attempts int64
+	w := NewWorker(
+		WithRetryDelayFunc(func(int64, error, *job.Message) time.Duration {
+			return backoff
+		}),
+		WithRunFunc(func(context.Context, queue.QueuedMessage) error {
+			atomic.AddInt64(&attempts, 1)
+			return errors.New("boom")
+		}),
+	)
+
+	if err := w.Queue(job.NewTask(func(context.Context) error { return nil }, job.WithRetryCount(1))); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+
+	go w.Run()
+	defer w.Shutdown()
+
+	deadline := time.Now().Add(backoff / 2)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt64(&attempts) > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backoff/4)
+	defer cancel()
+	if err := w.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Flush should still be waiting on the pending retry, got %v", err)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 2*backoff)
+	defer cancel2()
+	if err := w.Flush(ctx2); err != nil {
+		t.Fatalf("Flush after retry lands: %v", err)
+	}
+}